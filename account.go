@@ -0,0 +1,124 @@
+package anticaptcha
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// QueueStats reports the current load of an Anti-Captcha task queue, as
+// returned by /getQueueStats. See https://anti-captcha.com/apidoc for the
+// meaning of each queueId.
+type QueueStats struct {
+	Waiting float64 `json:"waiting"`
+	Load    float64 `json:"load"`
+	Bid     float64 `json:"bid"`
+	Speed   float64 `json:"speed"`
+	Total   float64 `json:"total"`
+}
+
+// GetBalance returns the account's remaining balance in USD.
+func (this *Client) GetBalance() (float64, error) {
+	return this.GetBalanceContext(context.Background())
+}
+
+// GetBalanceContext is GetBalance with a caller-supplied context.
+func (this *Client) GetBalanceContext(ctx context.Context) (float64, error) {
+	responseBody, err := this.post(ctx, "/getBalance", map[string]interface{}{
+		"clientKey": this.APIKey,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if errorID, ok := responseBody["errorId"].(float64); ok && errorID != 0 {
+		return 0, newAPIError(responseBody)
+	}
+
+	balance, ok := responseBody["balance"].(float64)
+	if !ok {
+		return 0, errors.New("anti-captcha: unknown response")
+	}
+	return balance, nil
+}
+
+// ReportIncorrectImageCaptcha reports that an ImageToTextTask's solution was
+// wrong, requesting a refund for taskID.
+func (this *Client) ReportIncorrectImageCaptcha(taskID float64) error {
+	return this.ReportIncorrectImageCaptchaContext(context.Background(), taskID)
+}
+
+// ReportIncorrectImageCaptchaContext is ReportIncorrectImageCaptcha with a
+// caller-supplied context.
+func (this *Client) ReportIncorrectImageCaptchaContext(ctx context.Context, taskID float64) error {
+	return this.reportTask(ctx, "/reportIncorrectImageCaptcha", taskID)
+}
+
+// ReportIncorrectRecaptcha reports that a reCAPTCHA/hCaptcha/etc. solution
+// was rejected by the target website, requesting a refund for taskID.
+func (this *Client) ReportIncorrectRecaptcha(taskID float64) error {
+	return this.ReportIncorrectRecaptchaContext(context.Background(), taskID)
+}
+
+// ReportIncorrectRecaptchaContext is ReportIncorrectRecaptcha with a
+// caller-supplied context.
+func (this *Client) ReportIncorrectRecaptchaContext(ctx context.Context, taskID float64) error {
+	return this.reportTask(ctx, "/reportIncorrectRecaptcha", taskID)
+}
+
+// ReportCorrectRecaptcha reports that a reCAPTCHA/hCaptcha/etc. solution was
+// accepted, feeding Anti-Captcha's machine-learning model for taskID.
+func (this *Client) ReportCorrectRecaptcha(taskID float64) error {
+	return this.ReportCorrectRecaptchaContext(context.Background(), taskID)
+}
+
+// ReportCorrectRecaptchaContext is ReportCorrectRecaptcha with a
+// caller-supplied context.
+func (this *Client) ReportCorrectRecaptchaContext(ctx context.Context, taskID float64) error {
+	return this.reportTask(ctx, "/reportCorrectRecaptcha", taskID)
+}
+
+func (this *Client) reportTask(ctx context.Context, path string, taskID float64) error {
+	responseBody, err := this.post(ctx, path, map[string]interface{}{
+		"clientKey": this.APIKey,
+		"taskId":    taskID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if errorID, ok := responseBody["errorId"].(float64); ok && errorID != 0 {
+		return newAPIError(responseBody)
+	}
+	return nil
+}
+
+// GetQueueStats returns the current waiters/load/bid/speed stats for the
+// given queueId, letting callers pick the task type with the shortest queue.
+func (this *Client) GetQueueStats(queueID int) (*QueueStats, error) {
+	return this.GetQueueStatsContext(context.Background(), queueID)
+}
+
+// GetQueueStatsContext is GetQueueStats with a caller-supplied context.
+func (this *Client) GetQueueStatsContext(ctx context.Context, queueID int) (*QueueStats, error) {
+	responseBody, err := this.post(ctx, "/getQueueStats", map[string]interface{}{
+		"queueId": queueID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if errorID, ok := responseBody["errorId"].(float64); ok && errorID != 0 {
+		return nil, newAPIError(responseBody)
+	}
+
+	b, err := json.Marshal(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	stats := &QueueStats{}
+	if err := json.Unmarshal(b, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -2,6 +2,7 @@ package anticaptcha
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/pkg/errors"
 	"net/http"
@@ -10,22 +11,72 @@ import (
 )
 
 var (
-	baseURL      = &url.URL{Host: "api.anti-captcha.com", Scheme: "https", Path: "/"}
-	sendInterval = 10 * time.Second
+	defaultBaseURL = &url.URL{Host: "api.anti-captcha.com", Scheme: "https", Path: "/"}
+	sendInterval   = 10 * time.Second
 )
 
 type Client struct {
 	APIKey string
 	c      *http.Client
+
+	baseURL   *url.URL
+	userAgent string
+	softID    int
+	logger    Logger
+
+	// translateTaskType remaps a task's "type" field before it is sent,
+	// letting a backend built on top of Client (see solver.go) speak a
+	// schema that names its task types differently.
+	translateTaskType func(string) string
+
+	// PollInitialDelay, if set, is waited out once before the first
+	// getTaskResult call, letting callers give a task type (e.g. image,
+	// ~5s) a head start before polling begins.
+	PollInitialDelay time.Duration
+	// PollMinInterval is the shortest wait PollBackoff is allowed to
+	// produce between polls. Defaults to 10s, Anti-Captcha's own
+	// recommended poll rate.
+	PollMinInterval time.Duration
+	// PollMaxInterval is the longest wait PollBackoff is allowed to
+	// produce between polls. Defaults to PollMinInterval.
+	PollMaxInterval time.Duration
+	// PollBackoff computes the wait before each poll. Defaults to
+	// ConstantBackoff().
+	PollBackoff Backoff
+	// MaxPollAttempts aborts polling with a *PollTimeoutError once this
+	// many getTaskResult calls have been made. 0 means unlimited.
+	MaxPollAttempts int
+	// MaxPollWait aborts polling with a *PollTimeoutError once this much
+	// time has elapsed since polling began, PollInitialDelay included. 0
+	// means unlimited.
+	MaxPollWait time.Duration
+}
+
+func NewClient(api string, opts ...ClientOption) *Client {
+	return NewClientWithHTTPClient(api, &http.Client{Timeout: time.Minute}, opts...)
 }
 
-func NewClient(api string) *Client {
-	return &Client{
-		APIKey: api,
-		c:      &http.Client{Timeout: time.Minute},
+// NewClientWithHTTPClient is NewClient but lets the caller supply their own
+// *http.Client, e.g. one with a custom TLS config, a corporate proxy, tracing
+// middleware on its Transport, or a shared connection pool.
+func NewClientWithHTTPClient(api string, hc *http.Client, opts ...ClientOption) *Client {
+	c := &Client{
+		APIKey:  api,
+		c:       hc,
+		baseURL: defaultBaseURL,
+		logger:  noopLogger{},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// UseDebug points the client's HTTP transport at a local mitmproxy-style
+// debugging proxy on localhost:8888.
+//
+// Deprecated: wire a *http.Transport with the proxy/tracing/TLS settings you
+// need and pass it via NewClientWithHTTPClient instead.
 func (this *Client) UseDebug() {
 	proxyURL, _ := url.Parse("http://localhost:8888")
 	this.c.Transport = &http.Transport{
@@ -33,194 +84,126 @@ func (this *Client) UseDebug() {
 	}
 }
 
-// Method to create the task to process the recaptcha, returns the task_id
-func (this *Client) createTaskRecaptcha(websiteURL string, recaptchaKey string) (float64, error) {
-	// Mount the data to be sent
-	body := map[string]interface{}{
-		"clientKey": this.APIKey,
-		"task": map[string]interface{}{
-			"type":       "NoCaptchaTaskProxyless",
-			"websiteURL": websiteURL,
-			"websiteKey": recaptchaKey,
-		},
-	}
-
+// post sends body as JSON to path and decodes the JSON response, honoring
+// ctx for both the dial/request and cancellation.
+func (this *Client) post(ctx context.Context, path string, body map[string]interface{}) (map[string]interface{}, error) {
 	b, err := json.Marshal(body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Make the request
-	u := baseURL.ResolveReference(&url.URL{Path: "/createTask"})
-	resp, err := this.c.Post(u.String(), "application/json", bytes.NewBuffer(b))
+	u := this.baseURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(b))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	// Decode response
-	responseBody := make(map[string]interface{})
-	err = json.NewDecoder(resp.Body).Decode(&responseBody)
-	if err != nil {
-		return 0, err
+	req.Header.Set("Content-Type", "application/json")
+	if this.userAgent != "" {
+		req.Header.Set("User-Agent", this.userAgent)
 	}
 
-	taskId, ok := responseBody["taskId"]
-	if ok {
-		return taskId.(float64), nil
-	}
+	this.logger.Printf("anti-captcha: POST %s", u.String())
 
-	_, ok = responseBody["errorId"]
-	if !ok {
-		return 0, errors.New("anti-captcha: unknown response")
+	resp, err := this.c.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	errorDescription, ok := responseBody["errorDescription"]
-	if !ok {
-		return 0, errors.New("anti-captcha: unknown error")
+	responseBody := make(map[string]interface{})
+	if err = json.NewDecoder(resp.Body).Decode(&responseBody); err != nil {
+		return nil, err
 	}
+	return responseBody, nil
+}
 
-	return 0, errors.New(errorDescription.(string))
+// Method to create the task to process the recaptcha, returns the task_id
+func (this *Client) createTaskRecaptcha(ctx context.Context, websiteURL string, recaptchaKey string, opts ...Option) (float64, error) {
+	task := map[string]interface{}{
+		"type":       "NoCaptchaTaskProxyless",
+		"websiteURL": websiteURL,
+		"websiteKey": recaptchaKey,
+	}
+	applyProxy(task, "NoCaptchaTask", resolveOptions(opts).proxy)
+	return this.createTask(ctx, task)
 }
 
-// Method to check the result of a given task, returns the json returned from the api
-func (this *Client) getTaskResult(taskID float64) (map[string]interface{}, error) {
-	// Mount the data to be sent
-	body := map[string]interface{}{
-		"clientKey": this.APIKey,
-		"taskId":    taskID,
-	}
-	b, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+// solution returns the "solution" object of a getTaskResult response. If the
+// task finished with an error instead of a solution, it returns the
+// corresponding *APIError so callers can use errors.Is against the sentinels
+// in errors.go.
+func solution(response map[string]interface{}) (map[string]interface{}, error) {
+	if errorID, ok := response["errorId"].(float64); ok && errorID != 0 {
+		return nil, newAPIError(response)
 	}
 
-	// Make the request
-	u := baseURL.ResolveReference(&url.URL{Path: "/getTaskResult"})
-	resp, err := this.c.Post(u.String(), "application/json", bytes.NewBuffer(b))
-	if err != nil {
-		return nil, err
+	solution, ok := response["solution"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("anticaptcha error")
 	}
-	defer resp.Body.Close()
-
-	// Decode response
-	responseBody := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&responseBody)
-	return responseBody, nil
+	return solution, nil
 }
 
 // SendRecaptcha Method to encapsulate the processing of the recaptcha
 // Given a url and a key, it sends to the api and waits until
-// the processing is complete to return the evaluated key
-func (this *Client) SendRecaptcha(websiteURL string, recaptchaKey string) (string, error) {
-	// Create the task on anti-captcha api and get the task_id
-	taskID, err := this.createTaskRecaptcha(websiteURL, recaptchaKey)
+// the processing is complete to return the evaluated key. Pass WithProxy to
+// route the challenge through a specific proxy instead of letting
+// Anti-Captcha's worker hit websiteURL directly.
+func (this *Client) SendRecaptcha(websiteURL string, recaptchaKey string, opts ...Option) (string, error) {
+	return this.SendRecaptchaContext(context.Background(), websiteURL, recaptchaKey, opts...)
+}
+
+// SendRecaptchaContext is SendRecaptcha with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendRecaptchaContext(ctx context.Context, websiteURL string, recaptchaKey string, opts ...Option) (string, error) {
+	taskID, err := this.createTaskRecaptcha(ctx, websiteURL, recaptchaKey, opts...)
 	if err != nil {
 		return "", err
 	}
 
-	// Check if the result is ready, if not loop until it is
-	response, err := this.getTaskResult(taskID)
+	response, err := this.waitForResult(ctx, taskID)
 	if err != nil {
 		return "", err
 	}
-	for {
-		if response["status"] == "processing" {
-			//log.Println("Result is not ready, waiting a few seconds to check again...")
-			time.Sleep(sendInterval)
-			response, err = this.getTaskResult(taskID)
-			if err != nil {
-				return "", err
-			}
-		} else {
-			//log.Println("Result is ready.")
-			break
-		}
-	}
 
-	if response["solution"] == nil {
-		return "", errors.New("solution is null")
+	sol, err := solution(response)
+	if err != nil {
+		return "", err
 	}
-	return response["solution"].(map[string]interface{})["gRecaptchaResponse"].(string), nil
+	return sol["gRecaptchaResponse"].(string), nil
 }
 
 // Method to create the task to process the image captcha, returns the task_id
-func (this *Client) createTaskImage(imgString string) (float64, error) {
-	// Mount the data to be sent
-	body := map[string]interface{}{
-		"clientKey": this.APIKey,
-		"task": map[string]interface{}{
-			"type": "ImageToTextTask",
-			"body": imgString,
-		},
-	}
-
-	b, err := json.Marshal(body)
-	if err != nil {
-		return 0, err
-	}
-
-	// Make the request
-	u := baseURL.ResolveReference(&url.URL{Path: "/createTask"})
-	resp, err := this.c.Post(u.String(), "application/json", bytes.NewBuffer(b))
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	// Decode response
-	responseBody := make(map[string]interface{})
-	if err = json.NewDecoder(resp.Body).Decode(&responseBody); err != nil {
-		return 0, err
-	}
-
-	val, ok := responseBody["taskId"]
-	if !ok || val == nil {
-		//fmt.Printf("%+v\n", responseBody)
-		return 0, errors.New("anticaptcha error")
-	}
-
-	switch val.(type) {
-	case float64:
-		return responseBody["taskId"].(float64), nil
-	default:
-		//fmt.Printf("%+v\n", responseBody)
-		return 0, errors.New("anticaptcha error")
-	}
+func (this *Client) createTaskImage(ctx context.Context, imgString string) (float64, error) {
+	return this.createTask(ctx, map[string]interface{}{
+		"type": "ImageToTextTask",
+		"body": imgString,
+	})
 }
 
 // SendImage Method to encapsulate the processing of the image captcha
 // Given a base64 string from the image, it sends to the api and waits until
 // the processing is complete to return the evaluated key
 func (this *Client) SendImage(imgString string) (string, error) {
-	// Create the task on anti-captcha api and get the task_id
-	taskID, err := this.createTaskImage(imgString)
+	return this.SendImageContext(context.Background(), imgString)
+}
+
+// SendImageContext is SendImage with a caller-supplied context, honored both
+// for the HTTP calls and while waiting between polls.
+func (this *Client) SendImageContext(ctx context.Context, imgString string) (string, error) {
+	taskID, err := this.createTaskImage(ctx, imgString)
 	if err != nil {
 		return "", err
 	}
 
-	// Check if the result is ready, if not loop until it is
-	response, err := this.getTaskResult(taskID)
+	response, err := this.waitForResult(ctx, taskID)
 	if err != nil {
 		return "", err
 	}
-	for {
-		if response["status"] == "processing" {
-			//log.Println("Result is not ready, waiting a few seconds to check again...")
-			time.Sleep(sendInterval)
-			response, err = this.getTaskResult(taskID)
-			if err != nil {
-				return "", err
-			}
-		} else {
-			//log.Println("Result is ready.")
-			break
-		}
-	}
 
-	if response["solution"] == nil {
-		return "", errors.New("anticaptcha error")
+	sol, err := solution(response)
+	if err != nil {
+		return "", err
 	}
-
-	return response["solution"].(map[string]interface{})["text"].(string), nil
+	return sol["text"].(string), nil
 }
@@ -0,0 +1,45 @@
+package anticaptcha
+
+import "net/url"
+
+// capsolverBaseURL is CapSolver's API host. CapSolver's createTask/
+// getTaskResult schema and error envelope are intentionally compatible with
+// Anti-Captcha's, so CapSolverSolver reuses Client wholesale and only swaps
+// the base URL and the task type names.
+const capsolverBaseURL = "https://api.capsolver.com/"
+
+// capsolverTaskTypes maps Anti-Captcha task type names to their CapSolver
+// equivalents, which mostly just differ in capitalization and use
+// "ReCaptchaV2" where Anti-Captcha uses "NoCaptcha".
+var capsolverTaskTypes = map[string]string{
+	"NoCaptchaTaskProxyless":  "ReCaptchaV2TaskProxyLess",
+	"NoCaptchaTask":           "ReCaptchaV2Task",
+	"HCaptchaTaskProxyless":   "HCaptchaTaskProxyLess",
+	"HCaptchaTask":            "HCaptchaTask",
+	"FunCaptchaTaskProxyless": "FunCaptchaTaskProxyLess",
+	"FunCaptchaTask":          "FunCaptchaTask",
+	"TurnstileTaskProxyless":  "AntiTurnstileTaskProxyLess",
+	"TurnstileTask":           "AntiTurnstileTask",
+}
+
+// CapSolverSolver is the CapSolver-backed Solver. It embeds *Client, so every
+// Send*/GetBalance method Client exposes is available here too.
+type CapSolverSolver struct {
+	*Client
+}
+
+// NewCapSolverSolver builds a Solver backed by api.capsolver.com.
+func NewCapSolverSolver(apiKey string, opts ...ClientOption) *CapSolverSolver {
+	base, _ := url.Parse(capsolverBaseURL)
+	c := NewClient(apiKey, opts...)
+	c.baseURL = base
+	c.translateTaskType = func(t string) string {
+		if translated, ok := capsolverTaskTypes[t]; ok {
+			return translated
+		}
+		return t
+	}
+	return &CapSolverSolver{Client: c}
+}
+
+var _ Solver = (*CapSolverSolver)(nil)
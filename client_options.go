@@ -0,0 +1,54 @@
+package anticaptcha
+
+import "net/url"
+
+// Logger is the subset of *log.Logger that Client uses to trace outgoing
+// requests when WithLogger is supplied.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Printf(string, ...interface{}) {}
+
+// ClientOption configures a Client at construction time, via NewClient or
+// NewClientWithHTTPClient.
+type ClientOption func(*Client)
+
+// WithBaseURL points the client at a different API host, e.g. to hit an
+// Anti-Captcha-compatible JSON endpoint or a mock server in tests.
+func WithBaseURL(rawURL string) ClientOption {
+	return func(c *Client) {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return
+		}
+		c.baseURL = u
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithSoftID tags every created task with Anti-Captcha's affiliate softId,
+// crediting this integration for the task volume it generates.
+func WithSoftID(softID int) ClientOption {
+	return func(c *Client) {
+		c.softID = softID
+	}
+}
+
+// WithLogger makes the client trace outgoing requests through logger instead
+// of discarding them.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package anticaptcha
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"math/rand"
+	"time"
+)
+
+// Backoff computes how long to wait before the next poll of getTaskResult,
+// given how many waits have already happened (0 on the first wait, which
+// follows the first poll).
+type Backoff func(attempt int, min, max time.Duration) time.Duration
+
+// ConstantBackoff waits the same interval between every poll.
+func ConstantBackoff() Backoff {
+	return func(_ int, min, _ time.Duration) time.Duration {
+		return min
+	}
+}
+
+// LinearBackoff grows the wait by min on every attempt, capped at max.
+func LinearBackoff() Backoff {
+	return func(attempt int, min, max time.Duration) time.Duration {
+		d := min * time.Duration(attempt+1)
+		if d > max {
+			return max
+		}
+		return d
+	}
+}
+
+// ExponentialBackoff doubles the wait on every attempt, capped at max, with
+// up to 20% jitter to avoid every in-flight task polling in lockstep.
+func ExponentialBackoff() Backoff {
+	return func(attempt int, min, max time.Duration) time.Duration {
+		d := min * time.Duration(uint64(1)<<uint(attempt))
+		if d > max || d <= 0 {
+			d = max
+		}
+		jitter := time.Duration(rand.Int63n(int64(d)/5 + 1))
+		if d+jitter > max {
+			return max
+		}
+		return d + jitter
+	}
+}
+
+// PollTimeoutError is returned by a SendXContext method when the task did not
+// finish within the Client's MaxPollAttempts/MaxPollWait budget.
+type PollTimeoutError struct {
+	TaskID   float64
+	Attempts int
+	Elapsed  time.Duration
+}
+
+func (e *PollTimeoutError) Error() string {
+	return fmt.Sprintf("anti-captcha: task %v did not finish after %d attempts (%s)", e.TaskID, e.Attempts, e.Elapsed)
+}
+
+// createTask submits a task payload to the /createTask endpoint and returns
+// the task_id assigned to it.
+func (this *Client) createTask(ctx context.Context, task map[string]interface{}) (float64, error) {
+	if this.translateTaskType != nil {
+		if t, ok := task["type"].(string); ok {
+			task["type"] = this.translateTaskType(t)
+		}
+	}
+
+	body := map[string]interface{}{
+		"clientKey": this.APIKey,
+		"task":      task,
+	}
+	if this.softID != 0 {
+		body["softId"] = this.softID
+	}
+
+	responseBody, err := this.post(ctx, "/createTask", body)
+	if err != nil {
+		return 0, err
+	}
+
+	taskId, ok := responseBody["taskId"]
+	if ok {
+		return taskId.(float64), nil
+	}
+
+	if _, ok := responseBody["errorId"]; !ok {
+		return 0, errors.New("anti-captcha: unknown response")
+	}
+
+	return 0, newAPIError(responseBody)
+}
+
+// getTaskResult checks the result of a given task, returning the decoded
+// response body as-is.
+func (this *Client) getTaskResult(ctx context.Context, taskID float64) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"clientKey": this.APIKey,
+		"taskId":    taskID,
+	}
+	return this.post(ctx, "/getTaskResult", body)
+}
+
+// waitForResult polls getTaskResult until the task leaves the "processing"
+// state, honoring ctx cancellation and the Client's poll backoff and
+// MaxPollAttempts/MaxPollWait budget.
+func (this *Client) waitForResult(ctx context.Context, taskID float64) (map[string]interface{}, error) {
+	start := time.Now()
+
+	if this.PollInitialDelay > 0 {
+		if err := sleepContext(ctx, this.PollInitialDelay); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := this.getTaskResult(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; response["status"] == "processing"; attempt++ {
+		calls := attempt + 1
+		if this.MaxPollAttempts > 0 && calls >= this.MaxPollAttempts {
+			return nil, &PollTimeoutError{TaskID: taskID, Attempts: calls, Elapsed: time.Since(start)}
+		}
+		if this.MaxPollWait > 0 && time.Since(start) >= this.MaxPollWait {
+			return nil, &PollTimeoutError{TaskID: taskID, Attempts: calls, Elapsed: time.Since(start)}
+		}
+
+		wait := this.pollBackoff()(attempt, this.pollMinInterval(), this.pollMaxInterval())
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		response, err = this.getTaskResult(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+func (this *Client) pollMinInterval() time.Duration {
+	if this.PollMinInterval > 0 {
+		return this.PollMinInterval
+	}
+	return sendInterval
+}
+
+func (this *Client) pollMaxInterval() time.Duration {
+	if this.PollMaxInterval > 0 {
+		return this.PollMaxInterval
+	}
+	return this.pollMinInterval()
+}
+
+func (this *Client) pollBackoff() Backoff {
+	if this.PollBackoff != nil {
+		return this.PollBackoff
+	}
+	return ConstantBackoff()
+}
+
+// sleepContext sleeps for d, returning early with ctx.Err() if ctx is done
+// first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
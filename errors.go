@@ -0,0 +1,96 @@
+package anticaptcha
+
+import "fmt"
+
+// APIError represents a failure reported by Anti-Captcha itself, as opposed
+// to a transport-level error. ErrorID and ErrorCode mirror the errorId and
+// errorCode fields Anti-Captcha returns, so callers can match against the
+// sentinel errors below with errors.Is instead of parsing ErrorDescription.
+type APIError struct {
+	ErrorID          uint32
+	ErrorCode        string
+	ErrorDescription string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("anti-captcha: %s: %s", e.ErrorCode, e.ErrorDescription)
+}
+
+// Is lets errors.Is(err, ErrCaptchaUnsolvable) match regardless of the
+// ErrorDescription text, which Anti-Captcha is free to change.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return e.ErrorID == t.ErrorID
+}
+
+// Sentinel errors for the Anti-Captcha errorId values callers are expected to
+// handle programmatically (auto-retry on a transient code, abort on an
+// account-level one, and so on).
+var (
+	ErrKeyDoesNotExist     = &APIError{ErrorID: 1, ErrorCode: "ERROR_KEY_DOES_NOT_EXIST"}
+	ErrZeroBalance         = &APIError{ErrorID: 10, ErrorCode: "ERROR_ZERO_BALANCE"}
+	ErrCaptchaUnsolvable   = &APIError{ErrorID: 12, ErrorCode: "ERROR_CAPTCHA_UNSOLVABLE"}
+	ErrNoSuchMethod        = &APIError{ErrorID: 14, ErrorCode: "ERROR_NO_SUCH_METHOD"}
+	ErrNoSuchCaptchaID     = &APIError{ErrorID: 16, ErrorCode: "ERROR_NO_SUCH_CAPCHA_ID"}
+	ErrIPBlocked           = &APIError{ErrorID: 21, ErrorCode: "ERROR_IP_BLOCKED"}
+	ErrTaskAbsent          = &APIError{ErrorID: 22, ErrorCode: "ERROR_TASK_ABSENT"}
+	ErrTaskNotSupported    = &APIError{ErrorID: 23, ErrorCode: "ERROR_TASK_NOT_SUPPORTED"}
+	ErrProxyConnectRefused = &APIError{ErrorID: 25, ErrorCode: "ERROR_PROXY_CONNECT_REFUSED"}
+)
+
+var sentinelErrorsByID = map[uint32]*APIError{
+	ErrKeyDoesNotExist.ErrorID:     ErrKeyDoesNotExist,
+	ErrZeroBalance.ErrorID:         ErrZeroBalance,
+	ErrCaptchaUnsolvable.ErrorID:   ErrCaptchaUnsolvable,
+	ErrNoSuchMethod.ErrorID:        ErrNoSuchMethod,
+	ErrNoSuchCaptchaID.ErrorID:     ErrNoSuchCaptchaID,
+	ErrIPBlocked.ErrorID:           ErrIPBlocked,
+	ErrTaskAbsent.ErrorID:          ErrTaskAbsent,
+	ErrTaskNotSupported.ErrorID:    ErrTaskNotSupported,
+	ErrProxyConnectRefused.ErrorID: ErrProxyConnectRefused,
+}
+
+// newAPIError builds an *APIError out of a decoded getTaskResult/createTask
+// response, using the ErrorCode from the known sentinels above when the
+// errorId matches one of them.
+func newAPIError(responseBody map[string]interface{}) error {
+	errorID, _ := responseBody["errorId"].(float64)
+	errorCode, _ := responseBody["errorCode"].(string)
+	errorDescription, _ := responseBody["errorDescription"].(string)
+
+	if sentinel, ok := sentinelErrorsByID[uint32(errorID)]; ok {
+		errorCode = sentinel.ErrorCode
+	}
+
+	return &APIError{
+		ErrorID:          uint32(errorID),
+		ErrorCode:        errorCode,
+		ErrorDescription: errorDescription,
+	}
+}
+
+var sentinelErrorsByCode = map[string]*APIError{
+	ErrKeyDoesNotExist.ErrorCode:     ErrKeyDoesNotExist,
+	ErrZeroBalance.ErrorCode:         ErrZeroBalance,
+	ErrCaptchaUnsolvable.ErrorCode:   ErrCaptchaUnsolvable,
+	ErrNoSuchMethod.ErrorCode:        ErrNoSuchMethod,
+	ErrNoSuchCaptchaID.ErrorCode:     ErrNoSuchCaptchaID,
+	ErrIPBlocked.ErrorCode:           ErrIPBlocked,
+	ErrTaskAbsent.ErrorCode:          ErrTaskAbsent,
+	ErrTaskNotSupported.ErrorCode:    ErrTaskNotSupported,
+	ErrProxyConnectRefused.ErrorCode: ErrProxyConnectRefused,
+}
+
+// newAPIErrorFromCode builds an *APIError out of a bare error code string,
+// as returned by 2Captcha/RuCaptcha's in.php/res.php endpoints. It maps to
+// one of the sentinels above when the code is recognized, so callers can
+// still use errors.Is against ErrZeroBalance and friends.
+func newAPIErrorFromCode(errorCode string) error {
+	if sentinel, ok := sentinelErrorsByCode[errorCode]; ok {
+		return sentinel
+	}
+	return &APIError{ErrorCode: errorCode, ErrorDescription: errorCode}
+}
@@ -0,0 +1,166 @@
+package anticaptcha
+
+import (
+	stderrors "errors"
+	"sync"
+)
+
+// Failover tries each Solver in order until one succeeds, so callers can
+// spread load across several captcha-solving accounts/providers and keep
+// working if one of them runs dry or can't crack a particular challenge.
+//
+// A Solver that returns ErrZeroBalance is skipped for the rest of the
+// Failover's lifetime. One that returns ErrCaptchaUnsolvable
+// MaxConsecutiveUnsolvable times in a row (default 3) is skipped the same
+// way, since a backend that can't solve a given challenge type once usually
+// can't solve it at all. Any other error just moves on to the next Solver for
+// that call.
+type Failover struct {
+	Solvers []Solver
+	// MaxConsecutiveUnsolvable is how many ErrCaptchaUnsolvable results in
+	// a row a Solver may return before Failover stops trying it. 0 means 3.
+	MaxConsecutiveUnsolvable int
+
+	mu         sync.Mutex
+	skipped    map[Solver]bool
+	unsolvable map[Solver]int
+}
+
+// NewFailover builds a Failover that tries solvers in the given order.
+func NewFailover(solvers ...Solver) *Failover {
+	return &Failover{Solvers: solvers}
+}
+
+func (this *Failover) maxConsecutiveUnsolvable() int {
+	if this.MaxConsecutiveUnsolvable > 0 {
+		return this.MaxConsecutiveUnsolvable
+	}
+	return 3
+}
+
+// try calls attempt with each non-skipped Solver in order until one returns
+// nil, returning the last error if none do.
+func (this *Failover) try(attempt func(Solver) error) error {
+	this.mu.Lock()
+	if this.skipped == nil {
+		this.skipped = map[Solver]bool{}
+	}
+	if this.unsolvable == nil {
+		this.unsolvable = map[Solver]int{}
+	}
+	this.mu.Unlock()
+
+	var lastErr error = stderrors.New("anti-captcha: no solvers configured")
+	for _, s := range this.Solvers {
+		this.mu.Lock()
+		skip := this.skipped[s]
+		this.mu.Unlock()
+		if skip {
+			continue
+		}
+
+		err := attempt(s)
+
+		this.mu.Lock()
+		switch {
+		case err == nil:
+			this.unsolvable[s] = 0
+		case stderrors.Is(err, ErrZeroBalance):
+			this.skipped[s] = true
+		case stderrors.Is(err, ErrCaptchaUnsolvable):
+			this.unsolvable[s]++
+			if this.unsolvable[s] >= this.maxConsecutiveUnsolvable() {
+				this.skipped[s] = true
+			}
+		default:
+			this.unsolvable[s] = 0
+		}
+		this.mu.Unlock()
+
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	return lastErr
+}
+
+func (this *Failover) SendRecaptcha(websiteURL string, recaptchaKey string, opts ...Option) (string, error) {
+	var result string
+	err := this.try(func(s Solver) error {
+		r, err := s.SendRecaptcha(websiteURL, recaptchaKey, opts...)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+func (this *Failover) SendImage(imgString string) (string, error) {
+	var result string
+	err := this.try(func(s Solver) error {
+		r, err := s.SendImage(imgString)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+func (this *Failover) SendHCaptcha(websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	var result string
+	err := this.try(func(s Solver) error {
+		r, err := s.SendHCaptcha(websiteURL, websiteKey, opts...)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+func (this *Failover) SendFunCaptcha(websiteURL string, websitePublicKey string, opts ...Option) (string, error) {
+	var result string
+	err := this.try(func(s Solver) error {
+		r, err := s.SendFunCaptcha(websiteURL, websitePublicKey, opts...)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+func (this *Failover) SendTurnstile(websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	var result string
+	err := this.try(func(s Solver) error {
+		r, err := s.SendTurnstile(websiteURL, websiteKey, opts...)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+func (this *Failover) GetBalance() (float64, error) {
+	var result float64
+	err := this.try(func(s Solver) error {
+		r, err := s.GetBalance()
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	})
+	return result, err
+}
+
+var _ Solver = (*Failover)(nil)
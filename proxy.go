@@ -0,0 +1,72 @@
+package anticaptcha
+
+// ProxyType is the protocol a Proxy speaks, as expected by Anti-Captcha's
+// proxyType task field.
+type ProxyType string
+
+const (
+	ProxyTypeHTTP   ProxyType = "http"
+	ProxyTypeSocks4 ProxyType = "socks4"
+	ProxyTypeSocks5 ProxyType = "socks5"
+)
+
+// Proxy describes the proxy a task's worker should use to reach the target
+// website. Supplying one via WithProxy switches the task to its non-Proxyless
+// variant.
+type Proxy struct {
+	Type      ProxyType
+	Address   string
+	Port      int
+	Login     string
+	Password  string
+	UserAgent string
+	Cookies   string
+}
+
+// Option configures an optional aspect of a Send* call, such as the proxy to
+// route the task's traffic through.
+type Option func(*options)
+
+type options struct {
+	proxy *Proxy
+}
+
+// WithProxy routes the task through the given proxy instead of letting
+// Anti-Captcha's worker hit the target website directly. This switches the
+// underlying task to its proxied variant (e.g. NoCaptchaTaskProxyless
+// becomes NoCaptchaTask).
+func WithProxy(proxy Proxy) Option {
+	return func(o *options) {
+		o.proxy = &proxy
+	}
+}
+
+func resolveOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// applyProxy switches task's "type" to proxiedType and fills in the proxy
+// fields when the caller supplied one via WithProxy. It is a no-op otherwise,
+// leaving task as the Proxyless variant.
+func applyProxy(task map[string]interface{}, proxiedType string, proxy *Proxy) {
+	if proxy == nil {
+		return
+	}
+
+	task["type"] = proxiedType
+	task["proxyType"] = string(proxy.Type)
+	task["proxyAddress"] = proxy.Address
+	task["proxyPort"] = proxy.Port
+	task["proxyLogin"] = proxy.Login
+	task["proxyPassword"] = proxy.Password
+	if proxy.UserAgent != "" {
+		task["userAgent"] = proxy.UserAgent
+	}
+	if proxy.Cookies != "" {
+		task["cookies"] = proxy.Cookies
+	}
+}
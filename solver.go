@@ -0,0 +1,16 @@
+package anticaptcha
+
+// Solver is the set of operations every supported captcha-solving backend
+// implements, so callers (and Failover) can swap Anti-Captcha, CapSolver or
+// 2Captcha/RuCaptcha in and out without changing call sites.
+type Solver interface {
+	SendRecaptcha(websiteURL string, recaptchaKey string, opts ...Option) (string, error)
+	SendImage(imgString string) (string, error)
+	SendHCaptcha(websiteURL string, websiteKey string, opts ...Option) (string, error)
+	SendFunCaptcha(websiteURL string, websitePublicKey string, opts ...Option) (string, error)
+	SendTurnstile(websiteURL string, websiteKey string, opts ...Option) (string, error)
+	GetBalance() (float64, error)
+}
+
+// *Client (the Anti-Captcha backend) is itself a Solver.
+var _ Solver = (*Client)(nil)
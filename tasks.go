@@ -0,0 +1,314 @@
+package anticaptcha
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// GeeTestV3Solution is the solution returned for a GeeTestTaskProxyless task
+// built from the v3 (gt/challenge) parameters.
+type GeeTestV3Solution struct {
+	Challenge string `json:"challenge"`
+	Validate  string `json:"validate"`
+	SecCode   string `json:"seccode"`
+}
+
+// GeeTestV4Solution is the solution returned for a GeeTestTaskProxyless task
+// built from the v4 initParameters.
+type GeeTestV4Solution struct {
+	CaptchaOutput string `json:"captcha_output"`
+	GenTime       string `json:"gen_time"`
+	LotNumber     string `json:"lot_number"`
+	PassToken     string `json:"pass_token"`
+	RiskType      string `json:"risk_type"`
+	CaptchaID     string `json:"captcha_id"`
+}
+
+// Method to create the task to process the hCaptcha, returns the task_id
+func (this *Client) createTaskHCaptcha(ctx context.Context, websiteURL string, websiteKey string, opts ...Option) (float64, error) {
+	task := map[string]interface{}{
+		"type":       "HCaptchaTaskProxyless",
+		"websiteURL": websiteURL,
+		"websiteKey": websiteKey,
+	}
+	applyProxy(task, "HCaptchaTask", resolveOptions(opts).proxy)
+	return this.createTask(ctx, task)
+}
+
+// SendHCaptcha Method to encapsulate the processing of the hCaptcha
+// Given a url and a site key, it sends to the api and waits until
+// the processing is complete to return the evaluated token. Pass WithProxy
+// to route the challenge through a specific proxy.
+func (this *Client) SendHCaptcha(websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	return this.SendHCaptchaContext(context.Background(), websiteURL, websiteKey, opts...)
+}
+
+// SendHCaptchaContext is SendHCaptcha with a caller-supplied context, honored
+// both for the HTTP calls and while waiting between polls.
+func (this *Client) SendHCaptchaContext(ctx context.Context, websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	taskID, err := this.createTaskHCaptcha(ctx, websiteURL, websiteKey, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	sol, err := solution(response)
+	if err != nil {
+		return "", err
+	}
+	return sol["gRecaptchaResponse"].(string), nil
+}
+
+// Method to create the task to process the FunCaptcha, returns the task_id
+func (this *Client) createTaskFunCaptcha(ctx context.Context, websiteURL string, websitePublicKey string, opts ...Option) (float64, error) {
+	task := map[string]interface{}{
+		"type":             "FunCaptchaTaskProxyless",
+		"websiteURL":       websiteURL,
+		"websitePublicKey": websitePublicKey,
+	}
+	applyProxy(task, "FunCaptchaTask", resolveOptions(opts).proxy)
+	return this.createTask(ctx, task)
+}
+
+// SendFunCaptcha Method to encapsulate the processing of the FunCaptcha
+// (Arkose Labs). Given a url and the public key, it sends to the api and
+// waits until the processing is complete to return the evaluated token. Pass
+// WithProxy to route the challenge through a specific proxy.
+func (this *Client) SendFunCaptcha(websiteURL string, websitePublicKey string, opts ...Option) (string, error) {
+	return this.SendFunCaptchaContext(context.Background(), websiteURL, websitePublicKey, opts...)
+}
+
+// SendFunCaptchaContext is SendFunCaptcha with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendFunCaptchaContext(ctx context.Context, websiteURL string, websitePublicKey string, opts ...Option) (string, error) {
+	taskID, err := this.createTaskFunCaptcha(ctx, websiteURL, websitePublicKey, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	sol, err := solution(response)
+	if err != nil {
+		return "", err
+	}
+	return sol["token"].(string), nil
+}
+
+// Method to create the task to process a v3 (gt/challenge) GeeTest, returns the task_id
+func (this *Client) createTaskGeeTestV3(ctx context.Context, websiteURL string, gt string, challenge string, opts ...Option) (float64, error) {
+	task := map[string]interface{}{
+		"type":       "GeeTestTaskProxyless",
+		"websiteURL": websiteURL,
+		"gt":         gt,
+		"challenge":  challenge,
+	}
+	applyProxy(task, "GeeTestTask", resolveOptions(opts).proxy)
+	return this.createTask(ctx, task)
+}
+
+// SendGeeTestV3 Method to encapsulate the processing of a v3 GeeTest
+// Given a url, the gt and challenge parameters found on the target page, it
+// sends to the api and waits until the processing is complete to return the
+// challenge/validate/seccode solution. Pass WithProxy to route the challenge
+// through a specific proxy.
+func (this *Client) SendGeeTestV3(websiteURL string, gt string, challenge string, opts ...Option) (*GeeTestV3Solution, error) {
+	return this.SendGeeTestV3Context(context.Background(), websiteURL, gt, challenge, opts...)
+}
+
+// SendGeeTestV3Context is SendGeeTestV3 with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendGeeTestV3Context(ctx context.Context, websiteURL string, gt string, challenge string, opts ...Option) (*GeeTestV3Solution, error) {
+	taskID, err := this.createTaskGeeTestV3(ctx, websiteURL, gt, challenge, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sol, err := solution(response)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(sol)
+	if err != nil {
+		return nil, err
+	}
+	geeTestSol := &GeeTestV3Solution{}
+	if err := json.Unmarshal(b, geeTestSol); err != nil {
+		return nil, err
+	}
+	return geeTestSol, nil
+}
+
+// Method to create the task to process a v4 GeeTest, returns the task_id
+func (this *Client) createTaskGeeTestV4(ctx context.Context, websiteURL string, initParameters map[string]interface{}, opts ...Option) (float64, error) {
+	task := map[string]interface{}{
+		"type":           "GeeTestTaskProxyless",
+		"websiteURL":     websiteURL,
+		"initParameters": initParameters,
+	}
+	applyProxy(task, "GeeTestTask", resolveOptions(opts).proxy)
+	return this.createTask(ctx, task)
+}
+
+// SendGeeTestV4 Method to encapsulate the processing of a v4 GeeTest
+// Given a url and the initParameters captured from the page's GeeTest
+// widget, it sends to the api and waits until the processing is complete to
+// return the solution. Pass WithProxy to route the challenge through a
+// specific proxy.
+func (this *Client) SendGeeTestV4(websiteURL string, initParameters map[string]interface{}, opts ...Option) (*GeeTestV4Solution, error) {
+	return this.SendGeeTestV4Context(context.Background(), websiteURL, initParameters, opts...)
+}
+
+// SendGeeTestV4Context is SendGeeTestV4 with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendGeeTestV4Context(ctx context.Context, websiteURL string, initParameters map[string]interface{}, opts ...Option) (*GeeTestV4Solution, error) {
+	taskID, err := this.createTaskGeeTestV4(ctx, websiteURL, initParameters, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sol, err := solution(response)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(sol)
+	if err != nil {
+		return nil, err
+	}
+	geeTestSol := &GeeTestV4Solution{}
+	if err := json.Unmarshal(b, geeTestSol); err != nil {
+		return nil, err
+	}
+	return geeTestSol, nil
+}
+
+// Method to create the task to process the Cloudflare Turnstile, returns the task_id
+func (this *Client) createTaskTurnstile(ctx context.Context, websiteURL string, websiteKey string, opts ...Option) (float64, error) {
+	task := map[string]interface{}{
+		"type":       "TurnstileTaskProxyless",
+		"websiteURL": websiteURL,
+		"websiteKey": websiteKey,
+	}
+	applyProxy(task, "TurnstileTask", resolveOptions(opts).proxy)
+	return this.createTask(ctx, task)
+}
+
+// SendTurnstile Method to encapsulate the processing of the Cloudflare
+// Turnstile. Given a url and a site key, it sends to the api and waits
+// until the processing is complete to return the evaluated token. Pass
+// WithProxy to route the challenge through a specific proxy.
+func (this *Client) SendTurnstile(websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	return this.SendTurnstileContext(context.Background(), websiteURL, websiteKey, opts...)
+}
+
+// SendTurnstileContext is SendTurnstile with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendTurnstileContext(ctx context.Context, websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	taskID, err := this.createTaskTurnstile(ctx, websiteURL, websiteKey, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	sol, err := solution(response)
+	if err != nil {
+		return "", err
+	}
+	return sol["token"].(string), nil
+}
+
+// Method to create the task to process a reCAPTCHA v3, returns the task_id
+func (this *Client) createTaskRecaptchaV3(ctx context.Context, websiteURL string, websiteKey string, minScore float64, pageAction string) (float64, error) {
+	return this.createTask(ctx, map[string]interface{}{
+		"type":       "RecaptchaV3TaskProxyless",
+		"websiteURL": websiteURL,
+		"websiteKey": websiteKey,
+		"minScore":   minScore,
+		"pageAction": pageAction,
+	})
+}
+
+// SendRecaptchaV3 Method to encapsulate the processing of a reCAPTCHA v3
+// Given a url, site key, the minimum score the caller needs and the page
+// action configured for the widget, it sends to the api and waits until the
+// processing is complete to return the evaluated key
+func (this *Client) SendRecaptchaV3(websiteURL string, websiteKey string, minScore float64, pageAction string) (string, error) {
+	return this.SendRecaptchaV3Context(context.Background(), websiteURL, websiteKey, minScore, pageAction)
+}
+
+// SendRecaptchaV3Context is SendRecaptchaV3 with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendRecaptchaV3Context(ctx context.Context, websiteURL string, websiteKey string, minScore float64, pageAction string) (string, error) {
+	taskID, err := this.createTaskRecaptchaV3(ctx, websiteURL, websiteKey, minScore, pageAction)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	sol, err := solution(response)
+	if err != nil {
+		return "", err
+	}
+	return sol["gRecaptchaResponse"].(string), nil
+}
+
+// Method to create an AntiGateTask, returns the task_id. AntiGateTask covers
+// arbitrary captcha forms that don't have a dedicated task type by letting
+// the worker follow a template with the given variables.
+func (this *Client) createTaskAntiGate(ctx context.Context, websiteURL string, templateName string, variables map[string]interface{}) (float64, error) {
+	return this.createTask(ctx, map[string]interface{}{
+		"type":         "AntiGateTask",
+		"websiteURL":   websiteURL,
+		"templateName": templateName,
+		"variables":    variables,
+	})
+}
+
+// SendAntiGate Method to encapsulate the processing of an AntiGateTask
+// Given a url, the template name configured in the Anti-Captcha dashboard
+// and the variables it expects, it sends to the api and waits until the
+// processing is complete to return the solution's answers
+func (this *Client) SendAntiGate(websiteURL string, templateName string, variables map[string]interface{}) (map[string]interface{}, error) {
+	return this.SendAntiGateContext(context.Background(), websiteURL, templateName, variables)
+}
+
+// SendAntiGateContext is SendAntiGate with a caller-supplied context,
+// honored both for the HTTP calls and while waiting between polls.
+func (this *Client) SendAntiGateContext(ctx context.Context, websiteURL string, templateName string, variables map[string]interface{}) (map[string]interface{}, error) {
+	taskID, err := this.createTaskAntiGate(ctx, websiteURL, templateName, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := this.waitForResult(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return solution(response)
+}
@@ -0,0 +1,189 @@
+package anticaptcha
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errTwoCaptchaNotReady is the internal sentinel used between result and
+// poll; it never escapes TwoCaptchaSolver's exported methods.
+var errTwoCaptchaNotReady = errors.New("2captcha: CAPCHA_NOT_READY")
+
+// TwoCaptchaSolver is the 2Captcha/RuCaptcha-backed Solver. Unlike
+// Anti-Captcha and CapSolver it speaks a form-encoded in.php/res.php API that
+// answers with plain "OK|<answer>" or "CAPCHA_NOT_READY" text instead of
+// JSON, so it talks to the API directly rather than through Client.
+type TwoCaptchaSolver struct {
+	APIKey string
+	// BaseURL defaults to https://2captcha.com; NewRuCaptchaSolver points
+	// it at https://rucaptcha.com instead.
+	BaseURL string
+	// PollInterval is the wait between res.php polls. Defaults to 10s.
+	PollInterval time.Duration
+
+	c *http.Client
+}
+
+// NewTwoCaptchaSolver builds a Solver backed by 2captcha.com.
+func NewTwoCaptchaSolver(apiKey string) *TwoCaptchaSolver {
+	return &TwoCaptchaSolver{
+		APIKey:       apiKey,
+		BaseURL:      "https://2captcha.com",
+		PollInterval: sendInterval,
+		c:            &http.Client{Timeout: time.Minute},
+	}
+}
+
+// NewRuCaptchaSolver builds a Solver backed by rucaptcha.com, 2Captcha's
+// Russian-market mirror with an identical API.
+func NewRuCaptchaSolver(apiKey string) *TwoCaptchaSolver {
+	s := NewTwoCaptchaSolver(apiKey)
+	s.BaseURL = "https://rucaptcha.com"
+	return s
+}
+
+func (this *TwoCaptchaSolver) submit(ctx context.Context, form url.Values) (string, error) {
+	form.Set("key", this.APIKey)
+	form.Set("json", "0")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.BaseURL+"/in.php", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	text, err := this.do(req)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(text, "OK|") {
+		return "", newAPIErrorFromCode(text)
+	}
+	return strings.TrimPrefix(text, "OK|"), nil
+}
+
+func (this *TwoCaptchaSolver) result(ctx context.Context, id string) (string, error) {
+	u := fmt.Sprintf("%s/res.php?key=%s&action=get&id=%s", this.BaseURL, url.QueryEscape(this.APIKey), url.QueryEscape(id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+
+	text, err := this.do(req)
+	if err != nil {
+		return "", err
+	}
+	if text == "CAPCHA_NOT_READY" {
+		return "", errTwoCaptchaNotReady
+	}
+	if !strings.HasPrefix(text, "OK|") {
+		return "", newAPIErrorFromCode(text)
+	}
+	return strings.TrimPrefix(text, "OK|"), nil
+}
+
+func (this *TwoCaptchaSolver) poll(ctx context.Context, id string) (string, error) {
+	for {
+		answer, err := this.result(ctx, id)
+		if err == nil {
+			return answer, nil
+		}
+		if err != errTwoCaptchaNotReady {
+			return "", err
+		}
+		if err := sleepContext(ctx, this.PollInterval); err != nil {
+			return "", err
+		}
+	}
+}
+
+func (this *TwoCaptchaSolver) do(req *http.Request) (string, error) {
+	resp, err := this.c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func applyTwoCaptchaProxy(form url.Values, proxy *Proxy) {
+	if proxy == nil {
+		return
+	}
+	form.Set("proxy", fmt.Sprintf("%s:%d", proxy.Address, proxy.Port))
+	form.Set("proxytype", strings.ToUpper(string(proxy.Type)))
+	if proxy.Login != "" {
+		form.Set("proxy", fmt.Sprintf("%s:%s@%s:%d", proxy.Login, proxy.Password, proxy.Address, proxy.Port))
+	}
+}
+
+func (this *TwoCaptchaSolver) SendRecaptcha(websiteURL string, recaptchaKey string, opts ...Option) (string, error) {
+	form := url.Values{"method": {"userrecaptcha"}, "googlekey": {recaptchaKey}, "pageurl": {websiteURL}}
+	applyTwoCaptchaProxy(form, resolveOptions(opts).proxy)
+	return this.solve(form)
+}
+
+func (this *TwoCaptchaSolver) SendImage(imgString string) (string, error) {
+	form := url.Values{"method": {"base64"}, "body": {imgString}}
+	return this.solve(form)
+}
+
+func (this *TwoCaptchaSolver) SendHCaptcha(websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	form := url.Values{"method": {"hcaptcha"}, "sitekey": {websiteKey}, "pageurl": {websiteURL}}
+	applyTwoCaptchaProxy(form, resolveOptions(opts).proxy)
+	return this.solve(form)
+}
+
+func (this *TwoCaptchaSolver) SendFunCaptcha(websiteURL string, websitePublicKey string, opts ...Option) (string, error) {
+	form := url.Values{"method": {"funcaptcha"}, "publickey": {websitePublicKey}, "pageurl": {websiteURL}}
+	applyTwoCaptchaProxy(form, resolveOptions(opts).proxy)
+	return this.solve(form)
+}
+
+func (this *TwoCaptchaSolver) SendTurnstile(websiteURL string, websiteKey string, opts ...Option) (string, error) {
+	form := url.Values{"method": {"turnstile"}, "sitekey": {websiteKey}, "pageurl": {websiteURL}}
+	applyTwoCaptchaProxy(form, resolveOptions(opts).proxy)
+	return this.solve(form)
+}
+
+func (this *TwoCaptchaSolver) solve(form url.Values) (string, error) {
+	ctx := context.Background()
+	id, err := this.submit(ctx, form)
+	if err != nil {
+		return "", err
+	}
+	return this.poll(ctx, id)
+}
+
+// GetBalance returns the account's remaining balance in USD.
+func (this *TwoCaptchaSolver) GetBalance() (float64, error) {
+	u := fmt.Sprintf("%s/res.php?key=%s&action=getbalance", this.BaseURL, url.QueryEscape(this.APIKey))
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	text, err := this.do(req)
+	if err != nil {
+		return 0, err
+	}
+	balance, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, errors.Errorf("2captcha: %s", text)
+	}
+	return balance, nil
+}
+
+var _ Solver = (*TwoCaptchaSolver)(nil)